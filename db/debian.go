@@ -42,14 +42,35 @@ func (r *RDBDriver) GetDebian(cveID string) (*models.DebianCVE, error) {
 
 // GetDebianMulti :
 func (r *RDBDriver) GetDebianMulti(cveIDs []string) (map[string]models.DebianCVE, error) {
+	return r.GetDebianBulk(cveIDs)
+}
+
+// GetDebianBulk fetches multiple Debian CVEs and their Package/Release associations
+// in a few queries per batch instead of issuing 1+N+M round-trips. cveIDs is
+// batched using the batch-size setting so the IN (...) clause stays within the
+// underlying driver's bound-parameter limit on large datasets.
+func (r *RDBDriver) GetDebianBulk(cveIDs []string) (map[string]models.DebianCVE, error) {
+	if len(cveIDs) == 0 {
+		return map[string]models.DebianCVE{}, nil
+	}
+
+	batchSize := viper.GetInt("batch-size")
+	if batchSize < 1 {
+		return nil, fmt.Errorf("Failed to set batch-size. err: batch-size option is not set properly")
+	}
+
 	m := map[string]models.DebianCVE{}
-	for _, cveID := range cveIDs {
-		cve, err := r.GetDebian(cveID)
-		if err != nil {
+	for idx := range chunkSlice(len(cveIDs), batchSize) {
+		cves := []models.DebianCVE{}
+		if err := r.conn.
+			Preload("Package.Release").
+			Where("cve_id IN (?)", cveIDs[idx.From:idx.To]).
+			Find(&cves).Error; err != nil {
+			log15.Error("Failed to get Debian bulk", "err", err)
 			return nil, err
 		}
-		if cve != nil {
-			m[cve.CveID] = *cve
+		for _, c := range cves {
+			m[c.CveID] = c
 		}
 	}
 	return m, nil
@@ -152,64 +173,119 @@ var debVerCodename = map[string]string{
 	"13": "trixie",
 }
 
+// resolveSourcePackageName maps a binary package name (e.g. "libssl3") to the Debian source
+// package name the tracker JSON is keyed by (e.g. "openssl") for the given codename, using the
+// debian_binary_packages table populated from the Sources index during fetch. If no
+// mapping is found, pkgName is assumed to already be a source package name.
+func (r *RDBDriver) resolveSourcePackageName(pkgName, codeName string) (string, error) {
+	type Result struct {
+		SourcePackageName string
+	}
+
+	result := Result{}
+	err := r.conn.
+		Table("debian_binary_packages").
+		Select("source_package_name").
+		Where("binary_package_name = ? AND product_name = ?", pkgName, codeName).
+		Take(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkgName, nil
+		}
+		log15.Error("Failed to resolve Debian source package name", "err", err)
+		return "", err
+	}
+	return result.SourcePackageName, nil
+}
+
 // GetUnfixedCvesDebian gets the CVEs related to debian_release.status = 'open', major, pkgName.
 func (r *RDBDriver) GetUnfixedCvesDebian(major, pkgName string) (map[string]models.DebianCVE, error) {
-	return r.getCvesDebianWithFixStatus(major, pkgName, "open")
+	return r.GetCvesDebianByStatus(major, pkgName, models.DebianFixStatusOpen)
 }
 
 // GetFixedCvesDebian gets the CVEs related to debian_release.status = 'resolved', major, pkgName.
 func (r *RDBDriver) GetFixedCvesDebian(major, pkgName string) (map[string]models.DebianCVE, error) {
-	return r.getCvesDebianWithFixStatus(major, pkgName, "resolved")
+	return r.GetCvesDebianByStatus(major, pkgName, models.DebianFixStatusResolved)
+}
+
+// GetCvesDebianByStatus gets the CVEs related to debian_release.status in statuses, major, pkgName.
+// Unlike GetUnfixedCvesDebian/GetFixedCvesDebian, callers can pass any of the raw tracker
+// statuses (e.g. undetermined, not-affected, postponed, end-of-life) instead of just open/resolved.
+func (r *RDBDriver) GetCvesDebianByStatus(major, pkgName string, statuses ...models.DebianFixStatus) (map[string]models.DebianCVE, error) {
+	return r.getCvesDebianWithFixStatus(major, pkgName, "", statuses...)
+}
+
+// GetCvesDebianByStatusAndSource is identical to GetCvesDebianByStatus, but lets a caller that
+// already knows the Debian source package name (e.g. from a Vuls scan result or a purl with
+// ?arch=source) pass it directly and skip the binary-to-source lookup.
+func (r *RDBDriver) GetCvesDebianByStatusAndSource(major, pkgName, sourceName string, statuses ...models.DebianFixStatus) (map[string]models.DebianCVE, error) {
+	return r.getCvesDebianWithFixStatus(major, pkgName, sourceName, statuses...)
 }
 
-func (r *RDBDriver) getCvesDebianWithFixStatus(major, pkgName, fixStatus string) (map[string]models.DebianCVE, error) {
+func (r *RDBDriver) getCvesDebianWithFixStatus(major, pkgName, sourceName string, statuses ...models.DebianFixStatus) (map[string]models.DebianCVE, error) {
 	codeName, ok := debVerCodename[major]
 	if !ok {
 		log15.Error("Debian %s is not supported yet", "err", major)
 		return nil, xerrors.Errorf("Failed to convert from major version to codename. err: Debian %s is not supported yet", major)
 	}
+	if len(statuses) == 0 {
+		return nil, xerrors.New("Failed to get cves of Debian. err: at least one DebianFixStatus is required")
+	}
+
+	if sourceName == "" {
+		resolved, err := r.resolveSourcePackageName(pkgName, codeName)
+		if err != nil {
+			return nil, err
+		}
+		sourceName = resolved
+	}
 
 	type Result struct {
 		DebianCveID int64
 	}
 
+	// Resolve the matching CVE IDs with a single query, then hydrate them
+	// all with one preloaded query instead of one query per CVE.
 	results := []Result{}
 	err := r.conn.
 		Table("debian_packages").
-		Select("debian_cve_id").
-		Where("package_name = ?", pkgName).
+		Select("DISTINCT debian_packages.debian_cve_id").
+		Joins("JOIN debian_releases ON debian_releases.debian_package_id = debian_packages.id").
+		Where("debian_packages.package_name = ? AND debian_releases.status IN (?) AND debian_releases.product_name = ?", sourceName, statuses, codeName).
 		Scan(&results).Error
 
 	if err != nil {
-		if fixStatus == "open" {
-			log15.Error("Failed to get unfixed cves of Debian", "err", err)
-		} else {
-			log15.Error("Failed to get fixed cves of Debian", "err", err)
-		}
+		log15.Error("Failed to get cves of Debian", "err", err)
 		return nil, err
 	}
+	if len(results) == 0 {
+		return map[string]models.DebianCVE{}, nil
+	}
 
-	m := map[string]models.DebianCVE{}
+	cveIDs := make([]int64, 0, len(results))
 	for _, res := range results {
-		debcve := models.DebianCVE{}
-		if err := r.conn.
-			Preload("Package.Release", "status = ? AND product_name = ?", fixStatus, codeName).
-			Preload("Package", "package_name = ?", pkgName).
-			Where(&models.DebianCVE{ID: res.DebianCveID}).
-			First(&debcve).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, xerrors.Errorf("Failed to get DebianCVE. DB relationship may be broken, use `$ gost fetch debian` to recreate DB. err: %w", err)
-			}
-			log15.Error("Failed to get DebianCVE", res.DebianCveID, err)
-			return nil, err
-		}
+		cveIDs = append(cveIDs, res.DebianCveID)
+	}
 
-		if len(debcve.Package) != 0 {
-			for _, pkg := range debcve.Package {
-				if len(pkg.Release) != 0 {
-					m[debcve.CveID] = debcve
-				}
+	cves := []models.DebianCVE{}
+	if err := r.conn.
+		Preload("Package", "package_name = ?", sourceName).
+		Preload("Package.Release", "status IN (?) AND product_name = ?", statuses, codeName).
+		Where("id IN (?)", cveIDs).
+		Find(&cves).Error; err != nil {
+		log15.Error("Failed to get DebianCVE", "err", err)
+		return nil, xerrors.Errorf("Failed to get DebianCVE. DB relationship may be broken, use `$ gost fetch debian` to recreate DB. err: %w", err)
+	}
+	if len(cves) != len(cveIDs) {
+		return nil, xerrors.Errorf("Failed to get DebianCVE. DB relationship may be broken, use `$ gost fetch debian` to recreate DB. err: expected %d CVEs, got %d", len(cveIDs), len(cves))
+	}
 
+	m := map[string]models.DebianCVE{}
+	for _, debcve := range cves {
+		for _, pkg := range debcve.Package {
+			if len(pkg.Release) != 0 {
+				m[debcve.CveID] = debcve
+				break
 			}
 		}
 	}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/inconshreveable/log15"
+	"github.com/spf13/viper"
+	"github.com/vulsio/gost/models"
+	"gorm.io/gorm"
+)
+
+// GetDebianAdvisory :
+func (r *RDBDriver) GetDebianAdvisory(advisoryID string) (*models.DebianAdvisory, error) {
+	a := models.DebianAdvisory{}
+	if err := r.conn.
+		Preload("DebianAdvisoryCVE").
+		Where(&models.DebianAdvisory{AdvisoryID: advisoryID}).
+		First(&a).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		log15.Error("Failed to get DebianAdvisory", "err", err)
+		return nil, err
+	}
+	return &a, nil
+}
+
+// GetDebianAdvisoriesByCveID :
+func (r *RDBDriver) GetDebianAdvisoriesByCveID(cveID string) ([]models.DebianAdvisory, error) {
+	ids := []int64{}
+	if err := r.conn.
+		Table("debian_advisory_cves").
+		Select("debian_advisory_id").
+		Where("cve_id = ?", cveID).
+		Scan(&ids).Error; err != nil {
+		log15.Error("Failed to get DebianAdvisoryCVE", "err", err)
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	as := []models.DebianAdvisory{}
+	if err := r.conn.
+		Preload("DebianAdvisoryCVE").
+		Where("id IN (?)", ids).
+		Find(&as).Error; err != nil {
+		log15.Error("Failed to get DebianAdvisory", "err", err)
+		return nil, err
+	}
+	return as, nil
+}
+
+// GetDebianAdvisoriesByPackage :
+func (r *RDBDriver) GetDebianAdvisoriesByPackage(major, pkgName string) ([]models.DebianAdvisory, error) {
+	codeName, ok := debVerCodename[major]
+	if !ok {
+		log15.Error("Debian %s is not supported yet", "err", major)
+		return nil, fmt.Errorf("Failed to convert from major version to codename. err: Debian %s is not supported yet", major)
+	}
+
+	as := []models.DebianAdvisory{}
+	if err := r.conn.
+		Preload("DebianAdvisoryCVE").
+		Where(&models.DebianAdvisory{Release: codeName, Package: pkgName}).
+		Find(&as).Error; err != nil {
+		log15.Error("Failed to get DebianAdvisory", "err", err)
+		return nil, err
+	}
+	return as, nil
+}
+
+// InsertDebianAdvisory :
+func (r *RDBDriver) InsertDebianAdvisory(advisories []models.DebianAdvisory) (err error) {
+	if err = r.deleteAndInsertDebianAdvisory(advisories); err != nil {
+		return fmt.Errorf("Failed to insert Debian Advisory data. err: %s", err)
+	}
+	return nil
+}
+
+func (r *RDBDriver) deleteAndInsertDebianAdvisory(advisories []models.DebianAdvisory) (err error) {
+	tx := r.conn.Begin()
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		tx.Commit()
+	}()
+
+	if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(models.DebianAdvisoryCVE{}).Error; err != nil {
+		return fmt.Errorf("Failed to delete DebianAdvisoryCVE. err: %s", err)
+	}
+	if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(models.DebianAdvisory{}).Error; err != nil {
+		return fmt.Errorf("Failed to delete DebianAdvisory. err: %s", err)
+	}
+
+	batchSize := viper.GetInt("batch-size")
+	if batchSize < 1 {
+		return fmt.Errorf("Failed to set batch-size. err: batch-size option is not set properly")
+	}
+
+	for idx := range chunkSlice(len(advisories), batchSize) {
+		if err = tx.Create(advisories[idx.From:idx.To]).Error; err != nil {
+			return fmt.Errorf("Failed to insert. err: %s", err)
+		}
+	}
+
+	return nil
+}
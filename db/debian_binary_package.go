@@ -0,0 +1,52 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/vulsio/gost/models"
+)
+
+// InsertDebianBinaryPackages stores the binary -> source package name mapping used by
+// resolveSourcePackageName, replacing whatever was previously stored for the given codenames.
+func (r *RDBDriver) InsertDebianBinaryPackages(bps []models.DebianBinaryPackage) (err error) {
+	if err = r.deleteAndInsertDebianBinaryPackages(bps); err != nil {
+		return fmt.Errorf("Failed to insert Debian binary package data. err: %s", err)
+	}
+	return nil
+}
+
+func (r *RDBDriver) deleteAndInsertDebianBinaryPackages(bps []models.DebianBinaryPackage) (err error) {
+	tx := r.conn.Begin()
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		tx.Commit()
+	}()
+
+	codenames := map[string]struct{}{}
+	for _, bp := range bps {
+		codenames[bp.ProductName] = struct{}{}
+	}
+	for codename := range codenames {
+		if err := tx.Where("product_name = ?", codename).Delete(models.DebianBinaryPackage{}).Error; err != nil {
+			return fmt.Errorf("Failed to delete DebianBinaryPackage. err: %s", err)
+		}
+	}
+
+	batchSize := viper.GetInt("batch-size")
+	if batchSize < 1 {
+		return fmt.Errorf("Failed to set batch-size. err: batch-size option is not set properly")
+	}
+
+	for idx := range chunkSlice(len(bps), batchSize) {
+		if err = tx.Create(bps[idx.From:idx.To]).Error; err != nil {
+			return fmt.Errorf("Failed to insert. err: %s", err)
+		}
+	}
+
+	return nil
+}
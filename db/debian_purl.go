@@ -0,0 +1,119 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/inconshreveable/log15"
+	debver "github.com/knqyf263/go-deb-version"
+	packageurl "github.com/package-url/packageurl-go"
+	"github.com/vulsio/gost/models"
+	"golang.org/x/xerrors"
+)
+
+// GetCvesByPurl gets the CVEs affecting the package identified by a Package URL, e.g.
+// "pkg:deb/debian/openssl@3.0.11-1?distro=debian-12". The distro qualifier's major version is
+// mapped through debVerCodename, the package name is resolved binary -> source if needed, and,
+// when a version is present, only CVEs the installed version is actually vulnerable to (per dpkg
+// version ordering against DebianRelease.FixedVersion) are returned.
+func (r *RDBDriver) GetCvesByPurl(purl string) (map[string]models.DebianCVE, error) {
+	major, name, sourceName, version, err := parsePurlForDebian(purl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both Open and Resolved releases are fetched: a Resolved release still carries the
+	// FixedVersion needed to tell whether the installed version is actually vulnerable.
+	m, err := r.GetCvesDebianByStatusAndSource(major, name, sourceName, models.DebianFixStatusOpen, models.DebianFixStatusResolved)
+	if err != nil {
+		return nil, err
+	}
+	return filterCvesByPurlVersion(m, version), nil
+}
+
+// parsePurlForDebian extracts the major Debian release version, package name, source package
+// name (if known), and installed version from a Package URL, for GetCvesByPurl callers on either
+// driver. sourceName is empty when the purl only identifies a binary package, leaving the caller
+// to resolve binary -> source itself.
+func parsePurlForDebian(purl string) (major, name, sourceName, version string, err error) {
+	instance, err := packageurl.FromString(purl)
+	if err != nil {
+		return "", "", "", "", xerrors.Errorf("Failed to parse purl. err: %w", err)
+	}
+	if instance.Type != packageurl.TypeDebian || instance.Namespace != "debian" {
+		return "", "", "", "", xerrors.Errorf("Failed to get cves by purl. err: unsupported purl %q, only pkg:deb/debian is supported", purl)
+	}
+
+	qualifiers := instance.Qualifiers.Map()
+	major = strings.TrimPrefix(qualifiers["distro"], "debian-")
+	if major == "" {
+		return "", "", "", "", xerrors.New("Failed to get cves by purl. err: purl is missing a distro=debian-<major> qualifier")
+	}
+
+	sourceName = qualifiers["source"]
+	if sourceName == "" && qualifiers["arch"] == "source" {
+		sourceName = instance.Name
+	}
+
+	return major, instance.Name, sourceName, instance.Version, nil
+}
+
+// filterCvesByPurlVersion narrows m down to the CVEs relevant to version, the installed version
+// parsed from a purl. With no version, only the still-Open (unfixed) CVEs are kept; with a
+// version, only the ones it's actually vulnerable to (per purlVersionIsVulnerable) are kept.
+func filterCvesByPurlVersion(m map[string]models.DebianCVE, version string) map[string]models.DebianCVE {
+	if version == "" {
+		unfixed := map[string]models.DebianCVE{}
+		for cveID, cve := range m {
+			if hasStatusRelease(cve, models.DebianFixStatusOpen) {
+				unfixed[cveID] = cve
+			}
+		}
+		return unfixed
+	}
+
+	installed, err := debver.NewVersion(version)
+	if err != nil {
+		log15.Warn("Failed to parse purl version, returning unfiltered results", "version", version, "err", err)
+		return m
+	}
+
+	filtered := map[string]models.DebianCVE{}
+	for cveID, cve := range m {
+		if purlVersionIsVulnerable(installed, cve) {
+			filtered[cveID] = cve
+		}
+	}
+	return filtered
+}
+
+func hasStatusRelease(cve models.DebianCVE, status models.DebianFixStatus) bool {
+	for _, pkg := range cve.Package {
+		for _, rel := range pkg.Release {
+			if rel.Status == string(status) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// purlVersionIsVulnerable reports whether installed is vulnerable to cve, i.e. whether it
+// precedes the FixedVersion of at least one release (or a release has no FixedVersion yet).
+func purlVersionIsVulnerable(installed debver.Version, cve models.DebianCVE) bool {
+	for _, pkg := range cve.Package {
+		for _, rel := range pkg.Release {
+			if rel.FixedVersion == "" {
+				return true
+			}
+			fixed, err := debver.NewVersion(rel.FixedVersion)
+			if err != nil {
+				// Can't compare, so don't mask a potential vulnerability.
+				return true
+			}
+			if installed.LessThan(fixed) {
+				return true
+			}
+		}
+	}
+	return false
+}
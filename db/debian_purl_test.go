@@ -0,0 +1,104 @@
+package db
+
+import (
+	"testing"
+
+	debver "github.com/knqyf263/go-deb-version"
+	"github.com/vulsio/gost/models"
+)
+
+func mustDebVersion(t *testing.T, v string) debver.Version {
+	t.Helper()
+	version, err := debver.NewVersion(v)
+	if err != nil {
+		t.Fatalf("failed to parse version %q: %s", v, err)
+	}
+	return version
+}
+
+func TestPurlVersionIsVulnerable(t *testing.T) {
+	cases := []struct {
+		name      string
+		installed string
+		cve       models.DebianCVE
+		want      bool
+	}{
+		{
+			name:      "no fixed version yet",
+			installed: "3.0.11-1",
+			cve: models.DebianCVE{Package: []models.DebianPackage{{Release: []models.DebianRelease{
+				{FixedVersion: ""},
+			}}}},
+			want: true,
+		},
+		{
+			name:      "installed version precedes fixed version",
+			installed: "3.0.11-1",
+			cve: models.DebianCVE{Package: []models.DebianPackage{{Release: []models.DebianRelease{
+				{FixedVersion: "3.0.11-1+deb12u1"},
+			}}}},
+			want: true,
+		},
+		{
+			name:      "installed version already at or past fixed version",
+			installed: "3.0.11-1+deb12u1",
+			cve: models.DebianCVE{Package: []models.DebianPackage{{Release: []models.DebianRelease{
+				{FixedVersion: "3.0.11-1+deb12u1"},
+			}}}},
+			want: false,
+		},
+		{
+			name:      "unparsable fixed version does not mask a vulnerability",
+			installed: "3.0.11-1",
+			cve: models.DebianCVE{Package: []models.DebianPackage{{Release: []models.DebianRelease{
+				{FixedVersion: "not a version"},
+			}}}},
+			want: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			installed := mustDebVersion(t, tt.installed)
+			if got := purlVersionIsVulnerable(installed, tt.cve); got != tt.want {
+				t.Errorf("purlVersionIsVulnerable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasStatusRelease(t *testing.T) {
+	cve := models.DebianCVE{Package: []models.DebianPackage{{Release: []models.DebianRelease{
+		{Status: "open"},
+		{Status: "resolved"},
+	}}}}
+
+	if !hasStatusRelease(cve, models.DebianFixStatusOpen) {
+		t.Error("expected hasStatusRelease to find the open release")
+	}
+	if hasStatusRelease(cve, models.DebianFixStatus("undetermined")) {
+		t.Error("did not expect hasStatusRelease to find an undetermined release")
+	}
+}
+
+func TestGetCvesByPurlInvalidPurls(t *testing.T) {
+	r := &RDBDriver{}
+
+	cases := []struct {
+		name string
+		purl string
+	}{
+		{"not a purl", "not-a-purl"},
+		{"wrong type", "pkg:npm/openssl@3.0.11-1?distro=debian-12"},
+		{"wrong namespace", "pkg:deb/ubuntu/openssl@3.0.11-1?distro=debian-12"},
+		{"missing distro qualifier", "pkg:deb/debian/openssl@3.0.11-1"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := r.GetCvesByPurl(tt.purl); err == nil {
+				t.Errorf("expected an error for purl %q, got none", tt.purl)
+			}
+		})
+	}
+}
@@ -0,0 +1,468 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/inconshreveable/log15"
+	"github.com/vulsio/gost/models"
+	"golang.org/x/xerrors"
+	pb "gopkg.in/cheggaaa/pb.v1"
+)
+
+// Redis key layout for Debian data (mirrors the RDB schema so both drivers can answer the same
+// queries; CVE lookups take one MGET, while advisory lookups issue one GET per advisory ID):
+//
+//	SET   debian-cve#<cveID>                 -> JSON-encoded models.DebianCVE
+//	SADD  debian-pkg#<codename>#<pkgName>    -> set of cveIDs whose Package/Release match pkgName+codename
+//	SET   debian-advisory#<advisoryID>       -> JSON-encoded models.DebianAdvisory
+//	SADD  debian-advisory-cve#<cveID>        -> set of advisoryIDs for a CVE
+//	SADD  debian-advisory-pkg#<codename>#<pkgName> -> set of advisoryIDs for a package
+//	HSET  debian-binary-pkg#<codename>       -> binaryPackageName -> sourcePackageName
+//
+// Each of the above except debian-binary-pkg also has a companion "-all" set (e.g. debian-cve-all)
+// recording every key currently populated, so a re-fetch can find and remove the previous
+// generation's data before writing the new one, the same way the RDB driver deletes the whole
+// table before re-inserting. debian-binary-pkg#<codename> is instead replaced per codename.
+const (
+	debianCveKeyFormat         = "debian-cve#%s"
+	debianPkgKeyFormat         = "debian-pkg#%s#%s"
+	debianAdvisoryKeyFormat    = "debian-advisory#%s"
+	debianAdvisoryCveKeyFormat = "debian-advisory-cve#%s"
+	debianAdvisoryPkgKeyFormat = "debian-advisory-pkg#%s#%s"
+	debianBinaryPkgKeyFormat   = "debian-binary-pkg#%s"
+
+	debianCveAllKey         = "debian-cve-all"
+	debianPkgAllKey         = "debian-pkg-all"
+	debianAdvisoryAllKey    = "debian-advisory-all"
+	debianAdvisoryCveAllKey = "debian-advisory-cve-all"
+	debianAdvisoryPkgAllKey = "debian-advisory-pkg-all"
+)
+
+// GetDebian :
+func (r *RedisDriver) GetDebian(cveID string) (*models.DebianCVE, error) {
+	m, err := r.GetDebianBulk([]string{cveID})
+	if err != nil {
+		return nil, err
+	}
+	if cve, ok := m[cveID]; ok {
+		return &cve, nil
+	}
+	return nil, nil
+}
+
+// GetDebianMulti :
+func (r *RedisDriver) GetDebianMulti(cveIDs []string) (map[string]models.DebianCVE, error) {
+	return r.GetDebianBulk(cveIDs)
+}
+
+// GetDebianBulk fetches multiple Debian CVEs with a single pipelined MGET instead of one round
+// trip per CVE ID.
+func (r *RedisDriver) GetDebianBulk(cveIDs []string) (map[string]models.DebianCVE, error) {
+	if len(cveIDs) == 0 {
+		return map[string]models.DebianCVE{}, nil
+	}
+
+	ctx := context.Background()
+	keys := make([]string, 0, len(cveIDs))
+	for _, cveID := range cveIDs {
+		keys = append(keys, fmt.Sprintf(debianCveKeyFormat, cveID))
+	}
+
+	values, err := r.conn.MGet(ctx, keys...).Result()
+	if err != nil {
+		log15.Error("Failed to get Debian bulk", "err", err)
+		return nil, xerrors.Errorf("Failed to get Debian bulk. err: %w", err)
+	}
+
+	m := make(map[string]models.DebianCVE, len(values))
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var cve models.DebianCVE
+		if err := json.Unmarshal([]byte(s), &cve); err != nil {
+			log15.Error("Failed to unmarshal Debian CVE", "err", err)
+			return nil, xerrors.Errorf("Failed to unmarshal Debian CVE. err: %w", err)
+		}
+		m[cve.CveID] = cve
+	}
+	return m, nil
+}
+
+// InsertDebian is the Redis-backed counterpart to RDBDriver.InsertDebian.
+func (r *RedisDriver) InsertDebian(cveJSON models.DebianJSON) error {
+	cves := ConvertDebian(cveJSON)
+	if err := r.deleteAndInsertDebian(cves); err != nil {
+		return xerrors.Errorf("Failed to insert Debian CVE data. err: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisDriver) deleteAndInsertDebian(cves []models.DebianCVE) error {
+	ctx := context.Background()
+
+	oldCveIDs, err := r.conn.SMembers(ctx, debianCveAllKey).Result()
+	if err != nil {
+		log15.Error("Failed to get old Debian CVE IDs", "err", err)
+		return xerrors.Errorf("Failed to get old Debian CVE IDs. err: %w", err)
+	}
+	for _, cveID := range oldCveIDs {
+		if err := r.conn.Del(ctx, fmt.Sprintf(debianCveKeyFormat, cveID)).Err(); err != nil {
+			log15.Error("Failed to delete old DebianCVE", "err", err)
+			return xerrors.Errorf("Failed to delete old DebianCVE. err: %w", err)
+		}
+	}
+
+	oldPkgKeys, err := r.conn.SMembers(ctx, debianPkgAllKey).Result()
+	if err != nil {
+		log15.Error("Failed to get old Debian package keys", "err", err)
+		return xerrors.Errorf("Failed to get old Debian package keys. err: %w", err)
+	}
+	for _, pkgKey := range oldPkgKeys {
+		if err := r.conn.Del(ctx, pkgKey).Err(); err != nil {
+			log15.Error("Failed to delete old Debian package set", "err", err)
+			return xerrors.Errorf("Failed to delete old Debian package set. err: %w", err)
+		}
+	}
+	if err := r.conn.Del(ctx, debianCveAllKey, debianPkgAllKey).Err(); err != nil {
+		log15.Error("Failed to delete old Debian index sets", "err", err)
+		return xerrors.Errorf("Failed to delete old Debian index sets. err: %w", err)
+	}
+
+	bar := pb.StartNew(len(cves))
+	for _, cve := range cves {
+		j, err := json.Marshal(cve)
+		if err != nil {
+			log15.Error("Failed to marshal DebianCVE", "err", err)
+			return xerrors.Errorf("Failed to marshal DebianCVE. err: %w", err)
+		}
+		if err := r.conn.Set(ctx, fmt.Sprintf(debianCveKeyFormat, cve.CveID), string(j), 0).Err(); err != nil {
+			log15.Error("Failed to set DebianCVE", "err", err)
+			return xerrors.Errorf("Failed to set DebianCVE. err: %w", err)
+		}
+		if err := r.conn.SAdd(ctx, debianCveAllKey, cve.CveID).Err(); err != nil {
+			log15.Error("Failed to update Debian CVE index", "err", err)
+			return xerrors.Errorf("Failed to update Debian CVE index. err: %w", err)
+		}
+
+		seenPkgKeys := map[string]struct{}{}
+		for _, pkg := range cve.Package {
+			for _, rel := range pkg.Release {
+				pkgKey := fmt.Sprintf(debianPkgKeyFormat, rel.ProductName, pkg.PackageName)
+				if err := r.conn.SAdd(ctx, pkgKey, cve.CveID).Err(); err != nil {
+					log15.Error("Failed to update Debian package set", "err", err)
+					return xerrors.Errorf("Failed to update Debian package set. err: %w", err)
+				}
+				if _, ok := seenPkgKeys[pkgKey]; ok {
+					continue
+				}
+				seenPkgKeys[pkgKey] = struct{}{}
+				if err := r.conn.SAdd(ctx, debianPkgAllKey, pkgKey).Err(); err != nil {
+					log15.Error("Failed to update Debian package index", "err", err)
+					return xerrors.Errorf("Failed to update Debian package index. err: %w", err)
+				}
+			}
+		}
+		bar.Increment()
+	}
+	bar.Finish()
+
+	return nil
+}
+
+// GetUnfixedCvesDebian gets the CVEs related to debian_release.status = 'open', major, pkgName.
+func (r *RedisDriver) GetUnfixedCvesDebian(major, pkgName string) (map[string]models.DebianCVE, error) {
+	return r.GetCvesDebianByStatus(major, pkgName, models.DebianFixStatusOpen)
+}
+
+// GetFixedCvesDebian gets the CVEs related to debian_release.status = 'resolved', major, pkgName.
+func (r *RedisDriver) GetFixedCvesDebian(major, pkgName string) (map[string]models.DebianCVE, error) {
+	return r.GetCvesDebianByStatus(major, pkgName, models.DebianFixStatusResolved)
+}
+
+// GetCvesDebianByStatus gets the CVEs related to debian_release.status in statuses, major, pkgName.
+func (r *RedisDriver) GetCvesDebianByStatus(major, pkgName string, statuses ...models.DebianFixStatus) (map[string]models.DebianCVE, error) {
+	return r.GetCvesDebianByStatusAndSource(major, pkgName, "", statuses...)
+}
+
+// GetCvesDebianByStatusAndSource is identical to GetCvesDebianByStatus, but lets a caller that
+// already knows the Debian source package name pass it directly and skip the binary-to-source
+// lookup.
+func (r *RedisDriver) GetCvesDebianByStatusAndSource(major, pkgName, sourceName string, statuses ...models.DebianFixStatus) (map[string]models.DebianCVE, error) {
+	codeName, ok := debVerCodename[major]
+	if !ok {
+		log15.Error("Debian %s is not supported yet", "err", major)
+		return nil, xerrors.Errorf("Failed to convert from major version to codename. err: Debian %s is not supported yet", major)
+	}
+	if len(statuses) == 0 {
+		return nil, xerrors.New("Failed to get cves of Debian. err: at least one DebianFixStatus is required")
+	}
+
+	if sourceName == "" {
+		resolved, err := r.resolveSourcePackageName(pkgName, codeName)
+		if err != nil {
+			return nil, err
+		}
+		sourceName = resolved
+	}
+
+	ctx := context.Background()
+	cveIDs, err := r.conn.SMembers(ctx, fmt.Sprintf(debianPkgKeyFormat, codeName, sourceName)).Result()
+	if err != nil {
+		log15.Error("Failed to get cves of Debian", "err", err)
+		return nil, xerrors.Errorf("Failed to get cves of Debian. err: %w", err)
+	}
+	if len(cveIDs) == 0 {
+		return map[string]models.DebianCVE{}, nil
+	}
+
+	cves, err := r.GetDebianBulk(cveIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]struct{}, len(statuses))
+	for _, s := range statuses {
+		wanted[string(s)] = struct{}{}
+	}
+
+	// Trim each CVE down to the matching package and releases, as the RDB driver's preloads do.
+	m := map[string]models.DebianCVE{}
+	for cveID, cve := range cves {
+		pkgs := []models.DebianPackage{}
+		for _, pkg := range cve.Package {
+			if pkg.PackageName != sourceName {
+				continue
+			}
+			rels := []models.DebianRelease{}
+			for _, rel := range pkg.Release {
+				if rel.ProductName != codeName {
+					continue
+				}
+				if _, ok := wanted[rel.Status]; ok {
+					rels = append(rels, rel)
+				}
+			}
+			if len(rels) != 0 {
+				pkg.Release = rels
+				pkgs = append(pkgs, pkg)
+			}
+		}
+		if len(pkgs) != 0 {
+			cve.Package = pkgs
+			m[cveID] = cve
+		}
+	}
+	return m, nil
+}
+
+// resolveSourcePackageName is the Redis-backed counterpart to RDBDriver.resolveSourcePackageName:
+// it maps a binary package name to its Debian source package name via the
+// debian-binary-pkg#<codename> hash populated during fetch. If no mapping is found, pkgName is
+// assumed to already be a source package name.
+func (r *RedisDriver) resolveSourcePackageName(pkgName, codeName string) (string, error) {
+	ctx := context.Background()
+	sourceName, err := r.conn.HGet(ctx, fmt.Sprintf(debianBinaryPkgKeyFormat, codeName), pkgName).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return pkgName, nil
+		}
+		log15.Error("Failed to resolve Debian source package name", "err", err)
+		return "", xerrors.Errorf("Failed to resolve Debian source package name. err: %w", err)
+	}
+	return sourceName, nil
+}
+
+// InsertDebianBinaryPackages is the Redis-backed counterpart to RDBDriver.InsertDebianBinaryPackages.
+func (r *RedisDriver) InsertDebianBinaryPackages(bps []models.DebianBinaryPackage) error {
+	if err := r.deleteAndInsertDebianBinaryPackages(bps); err != nil {
+		return xerrors.Errorf("Failed to insert Debian binary package data. err: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisDriver) deleteAndInsertDebianBinaryPackages(bps []models.DebianBinaryPackage) error {
+	ctx := context.Background()
+
+	codenames := map[string]struct{}{}
+	for _, bp := range bps {
+		codenames[bp.ProductName] = struct{}{}
+	}
+	for codename := range codenames {
+		if err := r.conn.Del(ctx, fmt.Sprintf(debianBinaryPkgKeyFormat, codename)).Err(); err != nil {
+			log15.Error("Failed to delete old Debian binary package mapping", "err", err)
+			return xerrors.Errorf("Failed to delete old Debian binary package mapping. err: %w", err)
+		}
+	}
+
+	for _, bp := range bps {
+		if err := r.conn.HSet(ctx, fmt.Sprintf(debianBinaryPkgKeyFormat, bp.ProductName), bp.BinaryPackageName, bp.SourcePackageName).Err(); err != nil {
+			log15.Error("Failed to set Debian binary package mapping", "err", err)
+			return xerrors.Errorf("Failed to set Debian binary package mapping. err: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetDebianAdvisory :
+func (r *RedisDriver) GetDebianAdvisory(advisoryID string) (*models.DebianAdvisory, error) {
+	ctx := context.Background()
+	s, err := r.conn.Get(ctx, fmt.Sprintf(debianAdvisoryKeyFormat, advisoryID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		log15.Error("Failed to get DebianAdvisory", "err", err)
+		return nil, xerrors.Errorf("Failed to get DebianAdvisory. err: %w", err)
+	}
+
+	var a models.DebianAdvisory
+	if err := json.Unmarshal([]byte(s), &a); err != nil {
+		log15.Error("Failed to unmarshal DebianAdvisory", "err", err)
+		return nil, xerrors.Errorf("Failed to unmarshal DebianAdvisory. err: %w", err)
+	}
+	return &a, nil
+}
+
+// GetDebianAdvisoriesByCveID :
+func (r *RedisDriver) GetDebianAdvisoriesByCveID(cveID string) ([]models.DebianAdvisory, error) {
+	ctx := context.Background()
+	ids, err := r.conn.SMembers(ctx, fmt.Sprintf(debianAdvisoryCveKeyFormat, cveID)).Result()
+	if err != nil {
+		log15.Error("Failed to get DebianAdvisory", "err", err)
+		return nil, xerrors.Errorf("Failed to get DebianAdvisory. err: %w", err)
+	}
+	return r.getDebianAdvisories(ids)
+}
+
+// GetDebianAdvisoriesByPackage :
+func (r *RedisDriver) GetDebianAdvisoriesByPackage(major, pkgName string) ([]models.DebianAdvisory, error) {
+	codeName, ok := debVerCodename[major]
+	if !ok {
+		log15.Error("Debian %s is not supported yet", "err", major)
+		return nil, xerrors.Errorf("Failed to convert from major version to codename. err: Debian %s is not supported yet", major)
+	}
+
+	ctx := context.Background()
+	ids, err := r.conn.SMembers(ctx, fmt.Sprintf(debianAdvisoryPkgKeyFormat, codeName, pkgName)).Result()
+	if err != nil {
+		log15.Error("Failed to get DebianAdvisory", "err", err)
+		return nil, xerrors.Errorf("Failed to get DebianAdvisory. err: %w", err)
+	}
+	return r.getDebianAdvisories(ids)
+}
+
+// InsertDebianAdvisory is the Redis-backed counterpart to RDBDriver.InsertDebianAdvisory.
+func (r *RedisDriver) InsertDebianAdvisory(advisories []models.DebianAdvisory) error {
+	if err := r.deleteAndInsertDebianAdvisory(advisories); err != nil {
+		return xerrors.Errorf("Failed to insert Debian Advisory data. err: %w", err)
+	}
+	return nil
+}
+
+func (r *RedisDriver) deleteAndInsertDebianAdvisory(advisories []models.DebianAdvisory) error {
+	ctx := context.Background()
+
+	oldAdvisoryIDs, err := r.conn.SMembers(ctx, debianAdvisoryAllKey).Result()
+	if err != nil {
+		log15.Error("Failed to get old DebianAdvisory IDs", "err", err)
+		return xerrors.Errorf("Failed to get old DebianAdvisory IDs. err: %w", err)
+	}
+	for _, id := range oldAdvisoryIDs {
+		if err := r.conn.Del(ctx, fmt.Sprintf(debianAdvisoryKeyFormat, id)).Err(); err != nil {
+			log15.Error("Failed to delete old DebianAdvisory", "err", err)
+			return xerrors.Errorf("Failed to delete old DebianAdvisory. err: %w", err)
+		}
+	}
+
+	oldCveKeys, err := r.conn.SMembers(ctx, debianAdvisoryCveAllKey).Result()
+	if err != nil {
+		log15.Error("Failed to get old DebianAdvisory CVE keys", "err", err)
+		return xerrors.Errorf("Failed to get old DebianAdvisory CVE keys. err: %w", err)
+	}
+	for _, key := range oldCveKeys {
+		if err := r.conn.Del(ctx, key).Err(); err != nil {
+			log15.Error("Failed to delete old DebianAdvisory CVE set", "err", err)
+			return xerrors.Errorf("Failed to delete old DebianAdvisory CVE set. err: %w", err)
+		}
+	}
+
+	oldPkgKeys, err := r.conn.SMembers(ctx, debianAdvisoryPkgAllKey).Result()
+	if err != nil {
+		log15.Error("Failed to get old DebianAdvisory package keys", "err", err)
+		return xerrors.Errorf("Failed to get old DebianAdvisory package keys. err: %w", err)
+	}
+	for _, key := range oldPkgKeys {
+		if err := r.conn.Del(ctx, key).Err(); err != nil {
+			log15.Error("Failed to delete old DebianAdvisory package set", "err", err)
+			return xerrors.Errorf("Failed to delete old DebianAdvisory package set. err: %w", err)
+		}
+	}
+	if err := r.conn.Del(ctx, debianAdvisoryAllKey, debianAdvisoryCveAllKey, debianAdvisoryPkgAllKey).Err(); err != nil {
+		log15.Error("Failed to delete old DebianAdvisory index sets", "err", err)
+		return xerrors.Errorf("Failed to delete old DebianAdvisory index sets. err: %w", err)
+	}
+
+	for _, a := range advisories {
+		j, err := json.Marshal(a)
+		if err != nil {
+			log15.Error("Failed to marshal DebianAdvisory", "err", err)
+			return xerrors.Errorf("Failed to marshal DebianAdvisory. err: %w", err)
+		}
+		if err := r.conn.Set(ctx, fmt.Sprintf(debianAdvisoryKeyFormat, a.AdvisoryID), string(j), 0).Err(); err != nil {
+			log15.Error("Failed to set DebianAdvisory", "err", err)
+			return xerrors.Errorf("Failed to set DebianAdvisory. err: %w", err)
+		}
+		if err := r.conn.SAdd(ctx, debianAdvisoryAllKey, a.AdvisoryID).Err(); err != nil {
+			log15.Error("Failed to update DebianAdvisory index", "err", err)
+			return xerrors.Errorf("Failed to update DebianAdvisory index. err: %w", err)
+		}
+
+		for _, cve := range a.DebianAdvisoryCVE {
+			cveKey := fmt.Sprintf(debianAdvisoryCveKeyFormat, cve.CveID)
+			if err := r.conn.SAdd(ctx, cveKey, a.AdvisoryID).Err(); err != nil {
+				log15.Error("Failed to update DebianAdvisory CVE set", "err", err)
+				return xerrors.Errorf("Failed to update DebianAdvisory CVE set. err: %w", err)
+			}
+			if err := r.conn.SAdd(ctx, debianAdvisoryCveAllKey, cveKey).Err(); err != nil {
+				log15.Error("Failed to update DebianAdvisory CVE index", "err", err)
+				return xerrors.Errorf("Failed to update DebianAdvisory CVE index. err: %w", err)
+			}
+		}
+
+		pkgKey := fmt.Sprintf(debianAdvisoryPkgKeyFormat, a.Release, a.Package)
+		if err := r.conn.SAdd(ctx, pkgKey, a.AdvisoryID).Err(); err != nil {
+			log15.Error("Failed to update DebianAdvisory package set", "err", err)
+			return xerrors.Errorf("Failed to update DebianAdvisory package set. err: %w", err)
+		}
+		if err := r.conn.SAdd(ctx, debianAdvisoryPkgAllKey, pkgKey).Err(); err != nil {
+			log15.Error("Failed to update DebianAdvisory package index", "err", err)
+			return xerrors.Errorf("Failed to update DebianAdvisory package index. err: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *RedisDriver) getDebianAdvisories(advisoryIDs []string) ([]models.DebianAdvisory, error) {
+	if len(advisoryIDs) == 0 {
+		return nil, nil
+	}
+
+	as := make([]models.DebianAdvisory, 0, len(advisoryIDs))
+	for _, id := range advisoryIDs {
+		a, err := r.GetDebianAdvisory(id)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			as = append(as, *a)
+		}
+	}
+	return as, nil
+}
@@ -0,0 +1,20 @@
+package db
+
+import (
+	"github.com/vulsio/gost/models"
+)
+
+// GetCvesByPurl is the Redis-backed counterpart to RDBDriver.GetCvesByPurl. See its doc comment
+// for the purl format and version-comparison semantics.
+func (r *RedisDriver) GetCvesByPurl(purl string) (map[string]models.DebianCVE, error) {
+	major, name, sourceName, version, err := parsePurlForDebian(purl)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := r.GetCvesDebianByStatusAndSource(major, name, sourceName, models.DebianFixStatusOpen, models.DebianFixStatusResolved)
+	if err != nil {
+		return nil, err
+	}
+	return filterCvesByPurlVersion(m, version), nil
+}
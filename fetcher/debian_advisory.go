@@ -0,0 +1,127 @@
+package fetcher
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+	"github.com/vulsio/gost/models"
+)
+
+// Debian Security Tracker publishes DSA/DLA/ELA in the same plain-text "list" format, e.g.:
+//
+//	[25 Jun 2024] DSA-5709-1 chromium - security update
+//		{CVE-2024-6100 CVE-2024-6101}
+//		[bookworm] - chromium 126.0.6478.114-1~deb12u1
+const (
+	dsaListURL = "https://salsa.debian.org/security-tracker-team/security-tracker/raw/master/data/DSA/list"
+	dlaListURL = "https://salsa.debian.org/security-tracker-team/security-tracker/raw/master/data/DLA/list"
+	elaListURL = "https://salsa.debian.org/security-tracker-team/security-tracker/raw/master/data/ELA/list"
+)
+
+var (
+	advisoryHeaderRe = regexp.MustCompile(`^\[([^\]]+)\]\s+((?:DSA|DLA|ELA)-\d+-\d+)\s+(\S+)\s+-\s+(.*)$`)
+	advisoryCvesRe   = regexp.MustCompile(`CVE-\d{4}-\d+`)
+	advisoryFixRe    = regexp.MustCompile(`^\[([^\]]+)\]\s+-\s+(\S+)\s+(\S+)$`)
+)
+
+// FetchDebianAdvisories downloads and parses the DSA, DLA, and ELA list files.
+func FetchDebianAdvisories() ([]models.DebianAdvisory, error) {
+	var advisories []models.DebianAdvisory
+	for _, url := range []string{dsaListURL, dlaListURL, elaListURL} {
+		parsed, err := fetchDebianAdvisoryList(url)
+		if err != nil {
+			return nil, err
+		}
+		advisories = append(advisories, parsed...)
+	}
+	return advisories, nil
+}
+
+func fetchDebianAdvisoryList(url string) ([]models.DebianAdvisory, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		log15.Error("Failed to fetch Debian advisory list", "url", url, "err", err)
+		return nil, fmt.Errorf("Failed to fetch Debian advisory list. err: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to fetch Debian advisory list. err: unexpected status code %d for %s", resp.StatusCode, url)
+	}
+	return parseDebianAdvisoryList(resp.Body)
+}
+
+func parseDebianAdvisoryList(r io.Reader) ([]models.DebianAdvisory, error) {
+	var advisories []models.DebianAdvisory
+
+	var (
+		advisoryID, description, publishedDate string
+		cveIDs                                 []string
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case advisoryHeaderRe.MatchString(line):
+			m := advisoryHeaderRe.FindStringSubmatch(line)
+			publishedDate, advisoryID, description = m[1], m[2], m[4]
+			cveIDs = nil
+		case strings.TrimSpace(line) == "":
+			// blank lines separate advisories
+		case advisoryID != "":
+			trimmed := strings.TrimSpace(line)
+			if fix := advisoryFixRe.FindStringSubmatch(trimmed); fix != nil {
+				// One advisory can list a fix per release (e.g. bookworm, bullseye);
+				// each release gets its own row sharing the same AdvisoryID.
+				cves := make([]models.DebianAdvisoryCVE, 0, len(cveIDs))
+				for _, cveID := range cveIDs {
+					cves = append(cves, models.DebianAdvisoryCVE{CveID: cveID})
+				}
+				advisories = append(advisories, models.DebianAdvisory{
+					AdvisoryID:        advisoryID,
+					Release:           fix[1],
+					Package:           fix[2],
+					FixedVersion:      fix[3],
+					Description:       description,
+					PublishedDate:     publishedDate,
+					DebianAdvisoryCVE: cves,
+				})
+			} else if matches := advisoryCvesRe.FindAllString(trimmed, -1); len(matches) > 0 {
+				cveIDs = append(cveIDs, matches...)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log15.Error("Failed to parse Debian advisory list", "err", err)
+		return nil, fmt.Errorf("Failed to parse Debian advisory list. err: %s", err)
+	}
+
+	return advisories, nil
+}
+
+// debianAdvisoryInserter is satisfied by both *db.RDBDriver and *db.RedisDriver, so
+// FetchAndInsertDebianAdvisories works against either backend.
+type debianAdvisoryInserter interface {
+	InsertDebianAdvisory(advisories []models.DebianAdvisory) error
+}
+
+// FetchAndInsertDebianAdvisories fetches the DSA/DLA/ELA lists and stores them via driver. It is
+// meant to be called from the same `gost fetch debian` flow that calls InsertDebian with the
+// tracker JSON.
+func FetchAndInsertDebianAdvisories(driver debianAdvisoryInserter) error {
+	advisories, err := FetchDebianAdvisories()
+	if err != nil {
+		return err
+	}
+	if err := driver.InsertDebianAdvisory(advisories); err != nil {
+		log15.Error("Failed to insert Debian advisories", "err", err)
+		return err
+	}
+	return nil
+}
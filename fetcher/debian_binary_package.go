@@ -0,0 +1,132 @@
+package fetcher
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+	"github.com/vulsio/gost/models"
+)
+
+// debianSourcesURLFormat is the Debian archive's per-suite Sources index, which lists every
+// source package together with the binary packages it builds (the "Binary:" field).
+const debianSourcesURLFormat = "https://deb.debian.org/debian/dists/%s/main/source/Sources.gz"
+
+// FetchDebianBinaryPackages downloads and parses the Sources index for codename (e.g.
+// "bookworm") and returns the binary -> source package name mapping used to resolve scanner
+// package names (which are usually binary names) to the source names the tracker JSON is keyed
+// by.
+func FetchDebianBinaryPackages(codename string) ([]models.DebianBinaryPackage, error) {
+	url := fmt.Sprintf(debianSourcesURLFormat, codename)
+	resp, err := http.Get(url)
+	if err != nil {
+		log15.Error("Failed to fetch Debian Sources index", "url", url, "err", err)
+		return nil, fmt.Errorf("Failed to fetch Debian Sources index. err: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to fetch Debian Sources index. err: unexpected status code %d for %s", resp.StatusCode, url)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decompress Debian Sources index. err: %s", err)
+	}
+	defer gz.Close()
+
+	return parseDebianSources(gz, codename)
+}
+
+// debianBinaryPackageInserter is satisfied by both *db.RDBDriver and *db.RedisDriver, so
+// FetchAndInsertDebianBinaryPackages works against either backend.
+type debianBinaryPackageInserter interface {
+	InsertDebianBinaryPackages(bps []models.DebianBinaryPackage) error
+}
+
+// FetchAndInsertDebianBinaryPackages fetches the Sources index for each codename and stores the
+// resulting binary -> source package name mapping via driver, so resolveSourcePackageName has
+// something to resolve against. It is meant to be called from the same `gost fetch debian`
+// flow that calls InsertDebian with the tracker JSON.
+func FetchAndInsertDebianBinaryPackages(driver debianBinaryPackageInserter, codenames []string) error {
+	var bps []models.DebianBinaryPackage
+	for _, codename := range codenames {
+		fetched, err := FetchDebianBinaryPackages(codename)
+		if err != nil {
+			return err
+		}
+		bps = append(bps, fetched...)
+	}
+
+	if err := driver.InsertDebianBinaryPackages(bps); err != nil {
+		log15.Error("Failed to insert Debian binary packages", "err", err)
+		return err
+	}
+	return nil
+}
+
+// parseDebianSources reads a (decompressed) Debian Sources control file and emits one
+// DebianBinaryPackage per binary package listed in each stanza's "Binary:" field. The field
+// wraps onto continuation lines (a leading single space, no new "Field:" prefix) for any source
+// package that builds many binaries (e.g. linux, gcc, postgresql-*), so those are accumulated
+// before the comma-separated list is split.
+func parseDebianSources(r io.Reader, codename string) ([]models.DebianBinaryPackage, error) {
+	var bps []models.DebianBinaryPackage
+
+	sourceName := ""
+	binaryField := ""
+	inBinaryField := false
+
+	emitBinaries := func() {
+		if sourceName == "" || binaryField == "" {
+			return
+		}
+		for _, b := range strings.Split(binaryField, ",") {
+			b = strings.TrimSpace(b)
+			if b == "" {
+				continue
+			}
+			bps = append(bps, models.DebianBinaryPackage{
+				BinaryPackageName: b,
+				SourcePackageName: sourceName,
+				ProductName:       codename,
+			})
+		}
+		binaryField = ""
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, " ") && inBinaryField:
+			binaryField += strings.TrimSpace(line)
+		case strings.HasPrefix(line, "Package: "):
+			emitBinaries()
+			inBinaryField = false
+			sourceName = strings.TrimSpace(strings.TrimPrefix(line, "Package: "))
+		case strings.HasPrefix(line, "Binary: "):
+			emitBinaries()
+			inBinaryField = true
+			binaryField = strings.TrimSpace(strings.TrimPrefix(line, "Binary: "))
+		case line == "":
+			emitBinaries()
+			inBinaryField = false
+			sourceName = ""
+		default:
+			emitBinaries()
+			inBinaryField = false
+		}
+	}
+	emitBinaries()
+	if err := scanner.Err(); err != nil {
+		log15.Error("Failed to parse Debian Sources index", "err", err)
+		return nil, fmt.Errorf("Failed to parse Debian Sources index. err: %s", err)
+	}
+
+	return bps, nil
+}
@@ -0,0 +1,23 @@
+package models
+
+// DebianAdvisory is a Debian Security Advisory/LTS Advisory/Extended LTS Advisory
+// (DSA/DLA/ELA) that fixed a package, as opposed to the per-CVE status tracked by DebianCVE.
+// One DSA/DLA/ELA can fix a package across several releases; each release gets its own row
+// sharing the same AdvisoryID.
+type DebianAdvisory struct {
+	ID                int64               `json:"-"`
+	AdvisoryID        string              `json:"advisoryID" gorm:"index:idx_debian_advisories_advisory_id"`
+	Release           string              `json:"release" gorm:"index:idx_debian_advisories_release"`
+	Package           string              `json:"package" gorm:"index:idx_debian_advisories_package"`
+	FixedVersion      string              `json:"fixedVersion"`
+	Description       string              `json:"description"`
+	PublishedDate     string              `json:"publishedDate"`
+	DebianAdvisoryCVE []DebianAdvisoryCVE `json:"cves"`
+}
+
+// DebianAdvisoryCVE associates a DebianAdvisory with the CVE(s) it fixes.
+type DebianAdvisoryCVE struct {
+	ID               int64  `json:"-"`
+	DebianAdvisoryID int64  `json:"-"`
+	CveID            string `json:"cveID" gorm:"index:idx_debian_advisory_cves_cve_id"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// DebianBinaryPackage maps a binary package name (as reported by a scanner, e.g. "libssl3") to
+// the Debian source package name the tracker JSON is keyed by (e.g. "openssl") for a given
+// release, so lookups by binary name can be resolved to the source name they need to query by.
+type DebianBinaryPackage struct {
+	ID                int64  `json:"-"`
+	BinaryPackageName string `json:"binaryPackageName" gorm:"index:idx_debian_binary_packages_binary_package_name"`
+	SourcePackageName string `json:"sourcePackageName"`
+	ProductName       string `json:"productName" gorm:"index:idx_debian_binary_packages_product_name"`
+}
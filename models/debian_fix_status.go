@@ -0,0 +1,18 @@
+package models
+
+// DebianFixStatus is the raw status the Debian Security Tracker assigns a CVE/package/release
+// combination. Unlike a simple open/resolved split, the tracker uses several of these to convey
+// why a CVE isn't (yet) fixed, so callers can choose which ones they care about.
+type DebianFixStatus string
+
+// Debian Security Tracker status values, persisted verbatim from the tracker JSON.
+const (
+	DebianFixStatusOpen         DebianFixStatus = "open"
+	DebianFixStatusResolved     DebianFixStatus = "resolved"
+	DebianFixStatusUndetermined DebianFixStatus = "undetermined"
+	DebianFixStatusNotAffected  DebianFixStatus = "not-affected"
+	DebianFixStatusPostponed    DebianFixStatus = "postponed"
+	DebianFixStatusEndOfLife    DebianFixStatus = "end-of-life"
+	DebianFixStatusNoDSA        DebianFixStatus = "<no-dsa>"
+	DebianFixStatusIgnored      DebianFixStatus = "<ignored>"
+)
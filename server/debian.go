@@ -0,0 +1,105 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/inconshreveable/log15"
+	"github.com/vulsio/gost/db"
+)
+
+// RegisterDebianRoutes wires the Debian advisory and purl lookup endpoints onto mux.
+func RegisterDebianRoutes(mux *http.ServeMux, driver *db.RDBDriver) {
+	mux.HandleFunc("/debian/advisory", handleGetDebianAdvisory(driver))
+	mux.HandleFunc("/debian/advisory/cve", handleGetDebianAdvisoriesByCveID(driver))
+	mux.HandleFunc("/debian/advisory/package", handleGetDebianAdvisoriesByPackage(driver))
+	mux.HandleFunc("/debian/purl", handleGetCvesByPurl(driver))
+}
+
+// handleGetDebianAdvisory handles `GET /debian/advisory?id=DSA-5678-1`.
+func handleGetDebianAdvisory(driver *db.RDBDriver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, "id is required", http.StatusBadRequest)
+			return
+		}
+
+		advisory, err := driver.GetDebianAdvisory(id)
+		if err != nil {
+			log15.Error("Failed to get Debian advisory", "err", err)
+			http.Error(w, "Failed to get Debian advisory", http.StatusInternalServerError)
+			return
+		}
+		if advisory == nil {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, advisory)
+	}
+}
+
+// handleGetDebianAdvisoriesByCveID handles `GET /debian/advisory/cve?cveID=CVE-2024-1234`.
+func handleGetDebianAdvisoriesByCveID(driver *db.RDBDriver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cveID := r.URL.Query().Get("cveID")
+		if cveID == "" {
+			http.Error(w, "cveID is required", http.StatusBadRequest)
+			return
+		}
+
+		advisories, err := driver.GetDebianAdvisoriesByCveID(cveID)
+		if err != nil {
+			log15.Error("Failed to get Debian advisories", "err", err)
+			http.Error(w, "Failed to get Debian advisories", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, advisories)
+	}
+}
+
+// handleGetDebianAdvisoriesByPackage handles `GET /debian/advisory/package?major=12&pkgName=openssl`.
+func handleGetDebianAdvisoriesByPackage(driver *db.RDBDriver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		major := r.URL.Query().Get("major")
+		pkgName := r.URL.Query().Get("pkgName")
+		if major == "" || pkgName == "" {
+			http.Error(w, "major and pkgName are required", http.StatusBadRequest)
+			return
+		}
+
+		advisories, err := driver.GetDebianAdvisoriesByPackage(major, pkgName)
+		if err != nil {
+			log15.Error("Failed to get Debian advisories", "err", err)
+			http.Error(w, "Failed to get Debian advisories", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, advisories)
+	}
+}
+
+// handleGetCvesByPurl handles `GET /debian/purl?purl=pkg:deb/debian/openssl@3.0.11-1?distro=debian-12`.
+func handleGetCvesByPurl(driver *db.RDBDriver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		purl := r.URL.Query().Get("purl")
+		if purl == "" {
+			http.Error(w, "purl is required", http.StatusBadRequest)
+			return
+		}
+
+		cves, err := driver.GetCvesByPurl(purl)
+		if err != nil {
+			log15.Error("Failed to get cves by purl", "err", err)
+			http.Error(w, "Failed to get cves by purl", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, cves)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log15.Error("Failed to encode response", "err", err)
+	}
+}